@@ -8,216 +8,27 @@
 // Prerequisites:
 //     go mod init snowrail-example
 //     go mod tidy
+//     go mod edit -replace github.com/Colombia-Blockchain/snowrail-core/go=../../go
+//     go get github.com/Colombia-Blockchain/snowrail-core/go/snowrail
+//     go get github.com/Colombia-Blockchain/snowrail-core/go/snowrail/pool
 
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strings"
-	"time"
-)
-
-// ============================================================================
-// Types
-// ============================================================================
-
-// ValidationRequest represents a URL validation request
-type ValidationRequest struct {
-	URL    string `json:"url"`
-	Amount int    `json:"amount,omitempty"`
-}
-
-// ValidationResult represents the validation response
-type ValidationResult struct {
-	ID             string       `json:"id"`
-	URL            string       `json:"url"`
-	Timestamp      string       `json:"timestamp"`
-	Duration       int          `json:"duration"`
-	CanPay         bool         `json:"canPay"`
-	TrustScore     int          `json:"trustScore"`
-	Confidence     float64      `json:"confidence"`
-	Risk           string       `json:"risk"`
-	Decision       string       `json:"decision"`
-	Checks         []CheckResult `json:"checks"`
-	MaxAmount      *int         `json:"maxAmount,omitempty"`
-	Warnings       []string     `json:"warnings,omitempty"`
-	BlockedReasons []string     `json:"blockedReasons,omitempty"`
-}
-
-// CheckResult represents a single security check result
-type CheckResult struct {
-	Type       string                 `json:"type"`
-	Category   string                 `json:"category"`
-	Name       string                 `json:"name"`
-	Passed     bool                   `json:"passed"`
-	Score      int                    `json:"score"`
-	Confidence float64                `json:"confidence"`
-	Risk       string                 `json:"risk"`
-	Details    map[string]interface{} `json:"details"`
-}
-
-// IntentRequest represents a payment intent creation request
-type IntentRequest struct {
-	URL       string `json:"url"`
-	Amount    int    `json:"amount"`
-	Sender    string `json:"sender"`
-	Recipient string `json:"recipient"`
-}
-
-// IntentResponse represents the payment intent response
-type IntentResponse struct {
-	Intent struct {
-		ID        string    `json:"id"`
-		Status    string    `json:"status"`
-		Amount    int       `json:"amount"`
-		Currency  string    `json:"currency"`
-		Token     string    `json:"token"`
-		Chain     string    `json:"chain"`
-		Sender    string    `json:"sender"`
-		Recipient string    `json:"recipient"`
-		ExpiresAt time.Time `json:"expiresAt"`
-	} `json:"intent"`
-	Validation struct {
-		ID         string `json:"id"`
-		TrustScore int    `json:"trustScore"`
-		Decision   string `json:"decision"`
-	} `json:"validation"`
-}
 
-// HealthResponse represents the health check response
-type HealthResponse struct {
-	Status    string `json:"status"`
-	Timestamp string `json:"timestamp"`
-	Treasury  string `json:"treasury"`
-}
-
-// ============================================================================
-// Client
-// ============================================================================
-
-// SnowRailClient is a client for SnowRail API
-type SnowRailClient struct {
-	BaseURL    string
-	HTTPClient *http.Client
-}
-
-// NewClient creates a new SnowRail client
-func NewClient(baseURL string) *SnowRailClient {
-	if baseURL == "" {
-		baseURL = "http://localhost:3000"
-	}
-
-	return &SnowRailClient{
-		BaseURL: strings.TrimRight(baseURL, "/"),
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
-}
-
-// ValidateURL validates if a URL is safe to pay
-func (c *SnowRailClient) ValidateURL(url string, amount int) (*ValidationResult, error) {
-	reqBody := ValidationRequest{
-		URL:    url,
-		Amount: amount,
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/v1/sentinel/validate",
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-	}
-
-	var result ValidationResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &result, nil
-}
-
-// CreateIntent creates a payment intent
-func (c *SnowRailClient) CreateIntent(url string, amount int, sender, recipient string) (*IntentResponse, error) {
-	reqBody := IntentRequest{
-		URL:       url,
-		Amount:    amount,
-		Sender:    sender,
-		Recipient: recipient,
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/v1/payments/x402/intent",
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-	}
-
-	var result IntentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &result, nil
-}
-
-// HealthCheck checks API server health
-func (c *SnowRailClient) HealthCheck() (*HealthResponse, error) {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/health")
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-	}
-
-	var result HealthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &result, nil
-}
+	"github.com/Colombia-Blockchain/snowrail-core/go/snowrail"
+	"github.com/Colombia-Blockchain/snowrail-core/go/snowrail/pool"
+)
 
 // ============================================================================
 // Helper Functions
 // ============================================================================
 
-func printValidationResult(result *ValidationResult) {
+func printValidationResult(result *snowrail.ValidationResult) {
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Println("VALIDATION RESULT")
 	fmt.Println(strings.Repeat("=", 60))
@@ -267,7 +78,7 @@ func main() {
 	fmt.Println("🔷 SnowRail Go Client Example\n")
 
 	// Initialize client
-	client := NewClient("http://localhost:3000")
+	client := snowrail.NewClient("http://localhost:3000")
 
 	// Check server health
 	fmt.Println("Checking API health...")
@@ -302,7 +113,7 @@ func main() {
 		printValidationResult(result2)
 	}
 
-	// Example 3: Batch validation
+	// Example 3: Batch validation using the worker pool
 	fmt.Println("\nExample 3: Batch validation...")
 	urls := []string{
 		"https://api.stripe.com",
@@ -317,20 +128,54 @@ func main() {
 		CanPay     bool
 	}
 
-	results := make([]URLResult, 0, len(urls))
-	fmt.Println("Validating multiple URLs:")
+	// Disable the client's own RetryPolicy here: the pool already retries
+	// each job up to MaxAttempts times, and a client-level RetryPolicy
+	// would compound with it (3 pool attempts x 3 client attempts = up to
+	// 9 requests to a failing host). See pool.Options.MaxAttempts.
+	poolClient := snowrail.NewClientWithOptions("http://localhost:3000", snowrail.ClientOptions{Retry: nil})
+
+	wp := pool.New(poolClient, pool.Options{
+		Concurrency:  4,
+		PerHostLimit: 2,
+		MaxAttempts:  3,
+		QueueSize:    len(urls),
+	})
+	defer wp.Drain()
+
+	type submitted struct {
+		URL string
+		Ch  <-chan pool.Result
+	}
 
+	submissions := make([]submitted, 0, len(urls))
 	for _, url := range urls {
-		result, err := client.ValidateURL(url, 100)
+		u := url
+		ch, err := wp.Submit(context.Background(), pool.Job{
+			Do: func(ctx context.Context) (interface{}, error) {
+				return poolClient.ValidateURLContext(ctx, u, 100)
+			},
+			Host: u,
+		})
 		if err != nil {
-			results = append(results, URLResult{URL: url, TrustScore: 0, CanPay: false})
-		} else {
-			results = append(results, URLResult{
-				URL:        url,
-				TrustScore: result.TrustScore,
-				CanPay:     result.CanPay,
-			})
+			fmt.Printf("✗ Failed to submit %s: %v\n", u, err)
+			continue
+		}
+		submissions = append(submissions, submitted{URL: u, Ch: ch})
+	}
+
+	results := make([]URLResult, 0, len(submissions))
+	for _, s := range submissions {
+		res := <-s.Ch
+		if res.Err != nil {
+			results = append(results, URLResult{URL: s.URL, TrustScore: 0, CanPay: false})
+			continue
 		}
+		vr := res.Value.(*snowrail.ValidationResult)
+		results = append(results, URLResult{
+			URL:        vr.URL,
+			TrustScore: vr.TrustScore,
+			CanPay:     vr.CanPay,
+		})
 	}
 
 	fmt.Printf("\n%-40s %-15s %s\n", "URL", "Trust Score", "Can Pay")
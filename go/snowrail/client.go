@@ -0,0 +1,407 @@
+// Package snowrail is a Go client for the SnowRail API.
+package snowrail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	snowrailerrors "github.com/Colombia-Blockchain/snowrail-core/go/snowrail/errors"
+)
+
+// ============================================================================
+// Types
+// ============================================================================
+
+// ValidationRequest represents a URL validation request
+type ValidationRequest struct {
+	URL    string `json:"url"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// ValidationResult represents the validation response
+type ValidationResult struct {
+	ID         string  `json:"id"`
+	URL        string  `json:"url"`
+	Timestamp  string  `json:"timestamp"`
+	Duration   int     `json:"duration"`
+	CanPay     bool    `json:"canPay"`
+	TrustScore int     `json:"trustScore"`
+	Confidence float64 `json:"confidence"`
+	Risk       string  `json:"risk"`
+	// Decision is "allow", "review", or "block". A blocked validation is
+	// a normal 200 response, not an error: check this field rather than
+	// expecting ValidateURL/ValidateURLContext to return an error for it.
+	Decision       string        `json:"decision"`
+	Checks         []CheckResult `json:"checks"`
+	MaxAmount      *int          `json:"maxAmount,omitempty"`
+	Warnings       []string      `json:"warnings,omitempty"`
+	BlockedReasons []string      `json:"blockedReasons,omitempty"`
+}
+
+// CheckResult represents a single security check result
+type CheckResult struct {
+	Type       string                 `json:"type"`
+	Category   string                 `json:"category"`
+	Name       string                 `json:"name"`
+	Passed     bool                   `json:"passed"`
+	Score      int                    `json:"score"`
+	Confidence float64                `json:"confidence"`
+	Risk       string                 `json:"risk"`
+	Details    map[string]interface{} `json:"details"`
+}
+
+// IntentRequest represents a payment intent creation request
+type IntentRequest struct {
+	URL       string `json:"url"`
+	Amount    int    `json:"amount"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+}
+
+// IntentResponse represents the payment intent response
+type IntentResponse struct {
+	Intent struct {
+		ID        string    `json:"id"`
+		Status    string    `json:"status"`
+		Amount    int       `json:"amount"`
+		Currency  string    `json:"currency"`
+		Token     string    `json:"token"`
+		Chain     string    `json:"chain"`
+		Sender    string    `json:"sender"`
+		Recipient string    `json:"recipient"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	} `json:"intent"`
+	Validation struct {
+		ID         string `json:"id"`
+		TrustScore int    `json:"trustScore"`
+		Decision   string `json:"decision"`
+	} `json:"validation"`
+}
+
+// HealthResponse represents the health check response
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+	Treasury  string `json:"treasury"`
+}
+
+// ============================================================================
+// Client
+// ============================================================================
+
+// SnowRailClient is a client for SnowRail API
+type SnowRailClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+
+	// validationTimeout, intentTimeout, and healthTimeout bound how long
+	// a single ValidateURL/CreateIntent/HealthCheck call (and their
+	// Context variants) may take, independent of HTTPClient.Timeout.
+	// Zero means no additional per-operation deadline is applied.
+	validationTimeout time.Duration
+	intentTimeout     time.Duration
+	healthTimeout     time.Duration
+
+	retry *snowrailerrors.RetryPolicy
+}
+
+// ClientOptions configures a SnowRailClient created via
+// NewClientWithOptions.
+type ClientOptions struct {
+	// Retry controls retry behavior for ValidateURL/HealthCheck (always
+	// eligible) and CreateIntent (eligible once an Idempotency-Key is
+	// attached, which the client does automatically). A nil Retry
+	// disables retries.
+	Retry *snowrailerrors.RetryPolicy
+}
+
+// NewClient creates a new SnowRail client using the default retry policy.
+// Equivalent to NewClientWithOptions(baseURL, ClientOptions{Retry:
+// snowrailerrors.DefaultRetryPolicy()}).
+func NewClient(baseURL string) *SnowRailClient {
+	return NewClientWithOptions(baseURL, ClientOptions{Retry: snowrailerrors.DefaultRetryPolicy()})
+}
+
+// NewClientWithOptions creates a new SnowRail client with explicit
+// options, such as a custom or disabled RetryPolicy.
+func NewClientWithOptions(baseURL string, opts ClientOptions) *SnowRailClient {
+	if baseURL == "" {
+		baseURL = "http://localhost:3000"
+	}
+
+	return &SnowRailClient{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		retry: opts.Retry,
+	}
+}
+
+// WithValidationTimeout sets a per-call deadline for ValidateURL and
+// ValidateURLContext, independent of the overall HTTPClient.Timeout. It
+// returns the client so it can be chained off NewClient.
+func (c *SnowRailClient) WithValidationTimeout(d time.Duration) *SnowRailClient {
+	c.validationTimeout = d
+	return c
+}
+
+// WithIntentTimeout sets a per-call deadline for CreateIntent and
+// CreateIntentContext.
+func (c *SnowRailClient) WithIntentTimeout(d time.Duration) *SnowRailClient {
+	c.intentTimeout = d
+	return c
+}
+
+// WithHealthTimeout sets a per-call deadline for HealthCheck and
+// HealthCheckContext.
+func (c *SnowRailClient) WithHealthTimeout(d time.Duration) *SnowRailClient {
+	c.healthTimeout = d
+	return c
+}
+
+// withOperationDeadline returns a derived context bounded by d, and the
+// cancel func the caller must defer. A zero d leaves ctx unchanged.
+func withOperationDeadline(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// do executes req, attaching the request ID carried on ctx (if any) as an
+// X-Request-ID header so logs on both sides correlate, retrying
+// retryable failures per c.retry when idempotent is true. It returns the
+// response status, headers, and fully-read body so callers don't need to
+// manage resp.Body lifetime across retries.
+func (c *SnowRailClient) do(ctx context.Context, req *http.Request, idempotent bool) (status int, header http.Header, body []byte, err error) {
+	if reqID, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set("X-Request-ID", reqID)
+	}
+
+	maxAttempts := 1
+	if idempotent && c.retry != nil {
+		maxAttempts = c.retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.Body != nil {
+			newBody, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return 0, nil, nil, &snowrailerrors.TransportError{Err: bodyErr}
+			}
+			req.Body = newBody
+		}
+
+		resp, doErr := c.HTTPClient.Do(req)
+		if doErr != nil {
+			err = &snowrailerrors.TransportError{Err: doErr}
+			if attempt >= maxAttempts || ctx.Err() != nil {
+				return 0, nil, nil, err
+			}
+			if !sleepForRetry(ctx, c.retry.Backoff(attempt, 0)) {
+				return 0, nil, nil, ctx.Err()
+			}
+			continue
+		}
+
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return resp.StatusCode, resp.Header, nil, &snowrailerrors.TransportError{Err: readErr}
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp.StatusCode, resp.Header, respBody, nil
+		}
+
+		apiErr := parseAPIError(resp.StatusCode, resp.Header, respBody)
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if attempt >= maxAttempts || !snowrailerrors.IsRetryable(apiErr) {
+			return resp.StatusCode, resp.Header, respBody, apiErr
+		}
+		if !sleepForRetry(ctx, c.retry.Backoff(attempt, retryAfter)) {
+			return resp.StatusCode, resp.Header, respBody, ctx.Err()
+		}
+	}
+}
+
+func sleepForRetry(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type apiErrorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	RequestID string `json:"requestId"`
+}
+
+func parseAPIError(statusCode int, header http.Header, body []byte) *snowrailerrors.APIError {
+	var parsed apiErrorBody
+	_ = json.Unmarshal(body, &parsed)
+
+	apiErr := &snowrailerrors.APIError{
+		StatusCode: statusCode,
+		Code:       parsed.Error.Code,
+		Message:    parsed.Error.Message,
+		RequestID:  parsed.RequestID,
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = string(body)
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = header.Get("X-Request-ID")
+	}
+	apiErr.RetryAfter = int(parseRetryAfter(header.Get("Retry-After")).Seconds())
+	return apiErr
+}
+
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// ValidateURL validates if a URL is safe to pay. It is a thin wrapper
+// over ValidateURLContext using context.Background().
+func (c *SnowRailClient) ValidateURL(url string, amount int) (*ValidationResult, error) {
+	return c.ValidateURLContext(context.Background(), url, amount)
+}
+
+// ValidateURLContext validates if a URL is safe to pay, honoring ctx
+// cancellation/deadlines and any per-call timeout set via
+// WithValidationTimeout.
+func (c *SnowRailClient) ValidateURLContext(ctx context.Context, url string, amount int) (*ValidationResult, error) {
+	ctx, cancel := withOperationDeadline(ctx, c.validationTimeout)
+	defer cancel()
+
+	reqBody := ValidationRequest{
+		URL:    url,
+		Amount: amount,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v1/sentinel/validate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	_, _, body, err := c.do(ctx, httpReq, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ValidationResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, &snowrailerrors.DecodeError{Err: err, Body: body}
+	}
+
+	return &result, nil
+}
+
+// CreateIntent creates a payment intent. It is a thin wrapper over
+// CreateIntentContext using context.Background().
+func (c *SnowRailClient) CreateIntent(url string, amount int, sender, recipient string) (*IntentResponse, error) {
+	return c.CreateIntentContext(context.Background(), url, amount, sender, recipient)
+}
+
+// CreateIntentContext creates a payment intent, honoring ctx
+// cancellation/deadlines and any per-call timeout set via
+// WithIntentTimeout.
+func (c *SnowRailClient) CreateIntentContext(ctx context.Context, url string, amount int, sender, recipient string) (*IntentResponse, error) {
+	ctx, cancel := withOperationDeadline(ctx, c.intentTimeout)
+	defer cancel()
+
+	reqBody := IntentRequest{
+		URL:       url,
+		Amount:    amount,
+		Sender:    sender,
+		Recipient: recipient,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v1/payments/x402/intent", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	// An Idempotency-Key makes CreateIntent safe to retry: without one,
+	// a retried request could create a duplicate payment intent.
+	idempotencyKey, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+
+	_, _, body, err := c.do(ctx, httpReq, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result IntentResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, &snowrailerrors.DecodeError{Err: err, Body: body}
+	}
+
+	return &result, nil
+}
+
+// HealthCheck checks API server health. It is a thin wrapper over
+// HealthCheckContext using context.Background().
+func (c *SnowRailClient) HealthCheck() (*HealthResponse, error) {
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext checks API server health, honoring ctx
+// cancellation/deadlines and any per-call timeout set via
+// WithHealthTimeout.
+func (c *SnowRailClient) HealthCheckContext(ctx context.Context) (*HealthResponse, error) {
+	ctx, cancel := withOperationDeadline(ctx, c.healthTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	_, _, body, err := c.do(ctx, httpReq, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result HealthResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, &snowrailerrors.DecodeError{Err: err, Body: body}
+	}
+
+	return &result, nil
+}
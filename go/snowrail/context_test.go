@@ -0,0 +1,73 @@
+package snowrail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidationTimeoutCancelsSlowRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil}).WithValidationTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := c.ValidateURLContext(context.Background(), "https://example.com", 100)
+	if err == nil {
+		t.Fatal("expected the per-call timeout to cancel the request")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("WithValidationTimeout did not cancel promptly: took %v", elapsed)
+	}
+}
+
+func TestRequestIDIsForwardedAsHeader(t *testing.T) {
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Request-ID")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+	ctx := WithRequestID(context.Background(), "req-123")
+
+	if _, err := c.HealthCheckContext(ctx); err != nil {
+		t.Fatalf("HealthCheckContext: %v", err)
+	}
+	if gotHeader != "req-123" {
+		t.Fatalf("X-Request-ID header = %q, want %q", gotHeader, "req-123")
+	}
+}
+
+func TestIntentTimeoutCancelsSlowRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil}).WithIntentTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := c.CreateIntentContext(context.Background(), "https://example.com", 100, "0xsender", "0xrecipient")
+	if err == nil {
+		t.Fatal("expected the per-call timeout to cancel the request")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("WithIntentTimeout did not cancel promptly: took %v", elapsed)
+	}
+}
@@ -0,0 +1,79 @@
+// Package errors provides a typed, classifiable error hierarchy for the
+// SnowRail client, so callers can distinguish transient failures from
+// permanent ones without string-matching error messages.
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// APIError is returned when SnowRail responds with any status other than
+// 200 OK. Code and Message come from the JSON error body when it decodes;
+// otherwise Message holds the raw body.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	// RetryAfter is the server-suggested backoff, parsed from a
+	// Retry-After header, or zero if none was sent.
+	RetryAfter int
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("snowrail: API error %d (%s): %s [request_id=%s]", e.StatusCode, e.Code, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("snowrail: API error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// TransportError wraps a failure that occurred before a response was
+// received, e.g. a DNS failure, connection refusal, or timeout.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("snowrail: transport error: %v", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError wraps a failure to decode a response body as the expected
+// type. Body holds the raw bytes that failed to decode, truncated by the
+// caller if desired, to aid debugging.
+type DecodeError struct {
+	Err  error
+	Body []byte
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("snowrail: failed to decode response: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether err represents a transient failure worth
+// retrying: transport errors, 429, and 5xx API errors.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 429 || apiErr.StatusCode >= 500
+	}
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
+
+// IsRateLimited reports whether err is an APIError with status 429.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 429
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"transport error", &TransportError{Err: errors.New("dial tcp: refused")}, true},
+		{"429", &APIError{StatusCode: 429}, true},
+		{"500", &APIError{StatusCode: 500}, true},
+		{"503", &APIError{StatusCode: 503}, true},
+		{"400", &APIError{StatusCode: 400}, false},
+		{"404", &APIError{StatusCode: 404}, false},
+		{"decode error", &DecodeError{Err: errors.New("bad json")}, false},
+		{"nil", nil, false},
+		{"wrapped transport error", fmt.Errorf("calling host: %w", &TransportError{Err: errors.New("timeout")}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"429", &APIError{StatusCode: 429}, true},
+		{"500", &APIError{StatusCode: 500}, false},
+		{"transport error", &TransportError{Err: errors.New("refused")}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRateLimited(tt.err); got != tt.want {
+				t.Errorf("IsRateLimited(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
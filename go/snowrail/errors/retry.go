@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how a SnowRailClient retries retryable failures.
+// Only idempotent operations (ValidateURL, HealthCheck) retry by default;
+// CreateIntent requires an Idempotency-Key (auto-generated by the client)
+// before it becomes eligible.
+//
+// If the client is called from inside a pool.Job, its retries compound
+// with pool.Options.MaxAttempts since each pool attempt runs the client's
+// retry loop to completion. Pass ClientOptions{Retry: nil} (via
+// NewClientWithOptions) to clients used inside a worker pool so only the
+// pool's retry budget applies.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of tries, including the first.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the exponential backoff applied
+	// between attempts.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy returns the policy NewClient uses: 3 attempts, 200ms
+// base delay, 5s max delay. A client built with NewClientWithOptions uses
+// only ClientOptions.Retry, so a nil Retry there disables retries.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// Backoff returns the jittered delay to wait before the given attempt
+// number (1-indexed) is retried, honoring retryAfter if the server sent
+// one (via a Retry-After header on a 429 or 503).
+func (p *RetryPolicy) Backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	d := p.BaseDelay << uint(attempt-1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) + 1))
+	return d/2 + jitter/2
+}
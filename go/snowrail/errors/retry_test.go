@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoffHonorsRetryAfter(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	got := p.Backoff(1, 7*time.Second)
+	if got != 7*time.Second {
+		t.Fatalf("Backoff with retryAfter = %v, want %v", got, 7*time.Second)
+	}
+}
+
+func TestRetryPolicyBackoffBoundedByMaxDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 2 * time.Second}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		got := p.Backoff(attempt, 0)
+		if got > p.MaxDelay {
+			t.Fatalf("Backoff(%d, 0) = %v, want <= %v", attempt, got, p.MaxDelay)
+		}
+		if got < 0 {
+			t.Fatalf("Backoff(%d, 0) = %v, want >= 0", attempt, got)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffGrowsWithAttempt(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Hour}
+
+	// Backoff is jittered within [d/2, d] of the unjittered ceiling d, and
+	// d doubles each attempt, so every sample must reach at least the
+	// previous attempt's ceiling.
+	prevCeiling := time.Duration(0)
+	for attempt := 1; attempt <= 5; attempt++ {
+		ceiling := p.BaseDelay << uint(attempt-1)
+		if ceiling <= prevCeiling {
+			t.Fatalf("attempt %d ceiling %v did not grow past previous %v", attempt, ceiling, prevCeiling)
+		}
+		got := p.Backoff(attempt, 0)
+		if got < prevCeiling || got > ceiling {
+			t.Fatalf("Backoff(%d, 0) = %v, want within [%v, %v]", attempt, got, prevCeiling, ceiling)
+		}
+		prevCeiling = ceiling
+	}
+}
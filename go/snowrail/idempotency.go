@@ -0,0 +1,21 @@
+package snowrail
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	snowrailerrors "github.com/Colombia-Blockchain/snowrail-core/go/snowrail/errors"
+)
+
+// newIdempotencyKey returns a random UUID v4 suitable for an
+// Idempotency-Key header.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", &snowrailerrors.TransportError{Err: fmt.Errorf("failed to generate idempotency key: %w", err)}
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,210 @@
+package snowrail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	snowrailerrors "github.com/Colombia-Blockchain/snowrail-core/go/snowrail/errors"
+)
+
+// PaymentMethod is a discriminated payment instruction accepted by
+// CreateIntentWithMethod. Concrete implementations are EVMPayment,
+// BitcoinPayment, and LightningPayment.
+type PaymentMethod interface {
+	// paymentMethodType returns the discriminator the backend uses to
+	// decode the payload, e.g. "evm", "bitcoin", "lightning".
+	paymentMethodType() string
+}
+
+// EVMPayment is a PaymentMethod for EVM-compatible chains.
+type EVMPayment struct {
+	Chain     string `json:"chain"`
+	Token     string `json:"token"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+}
+
+func (EVMPayment) paymentMethodType() string { return "evm" }
+
+// BitcoinPayment is a PaymentMethod settled on-chain via Bitcoin.
+type BitcoinPayment struct {
+	Address               string `json:"address"`
+	SatAmount             int64  `json:"satAmount"`
+	ConfirmationsRequired int    `json:"confirmationsRequired"`
+}
+
+func (BitcoinPayment) paymentMethodType() string { return "bitcoin" }
+
+// LightningPayment is a PaymentMethod settled over the Lightning Network.
+type LightningPayment struct {
+	BOLT11Invoice string `json:"bolt11Invoice"`
+}
+
+func (LightningPayment) paymentMethodType() string { return "lightning" }
+
+// intentMethodRequest is the discriminated wire format CreateIntentWithMethod
+// sends: {"url", "amount", "method": {"type": "...", ...fields}}.
+type intentMethodRequest struct {
+	URL    string          `json:"url"`
+	Amount int             `json:"amount"`
+	Method json.RawMessage `json:"method"`
+}
+
+func marshalPaymentMethod(method PaymentMethod) (json.RawMessage, error) {
+	payload, err := json.Marshal(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment method: %w", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("failed to marshal payment method: %w", err)
+	}
+	fields["type"] = method.paymentMethodType()
+
+	return json.Marshal(fields)
+}
+
+// CreateIntentWithMethod creates a payment intent for any supported
+// PaymentMethod, marshaling the discriminated JSON the backend expects.
+func (c *SnowRailClient) CreateIntentWithMethod(ctx context.Context, url string, amount int, method PaymentMethod) (*IntentResponse, error) {
+	ctx, cancel := withOperationDeadline(ctx, c.intentTimeout)
+	defer cancel()
+
+	methodJSON, err := marshalPaymentMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := intentMethodRequest{
+		URL:    url,
+		Amount: amount,
+		Method: methodJSON,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v1/payments/x402/intent", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	idempotencyKey, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+
+	_, _, body, err := c.do(ctx, httpReq, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result IntentResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, &snowrailerrors.DecodeError{Err: err, Body: body}
+	}
+
+	return &result, nil
+}
+
+// ConfirmationUpdate reports the confirmation progress of a Bitcoin
+// intent being watched by WatchBitcoinIntent.
+type ConfirmationUpdate struct {
+	Confirmations int
+	Required      int
+	Status        string
+	Err           error
+}
+
+type bitcoinIntentStatus struct {
+	Status                string `json:"status"`
+	Confirmations         int    `json:"confirmations"`
+	ConfirmationsRequired int    `json:"confirmationsRequired"`
+}
+
+// WatchBitcoinIntent polls intent status until it reaches the configured
+// confirmation threshold or a terminal state, sending a ConfirmationUpdate
+// on the returned channel after every poll. The channel is closed once a
+// terminal status is reached, ctx is canceled, or a poll returns an error.
+func (c *SnowRailClient) WatchBitcoinIntent(ctx context.Context, intentID string) (<-chan ConfirmationUpdate, error) {
+	updates := make(chan ConfirmationUpdate)
+
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			status, err := c.pollBitcoinIntent(ctx, intentID)
+			if err != nil {
+				select {
+				case updates <- ConfirmationUpdate{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			update := ConfirmationUpdate{
+				Confirmations: status.Confirmations,
+				Required:      status.ConfirmationsRequired,
+				Status:        status.Status,
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+
+			if isTerminalBitcoinStatus(status) {
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+func isTerminalBitcoinStatus(status *bitcoinIntentStatus) bool {
+	if status.Confirmations >= status.ConfirmationsRequired && status.ConfirmationsRequired > 0 {
+		return true
+	}
+	switch status.Status {
+	case "confirmed", "failed", "expired":
+		return true
+	}
+	return false
+}
+
+func (c *SnowRailClient) pollBitcoinIntent(ctx context.Context, intentID string) (*bitcoinIntentStatus, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/v1/payments/x402/intent/"+intentID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	_, _, body, err := c.do(ctx, httpReq, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var status bitcoinIntentStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, &snowrailerrors.DecodeError{Err: err, Body: body}
+	}
+
+	return &status, nil
+}
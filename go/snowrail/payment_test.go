@@ -0,0 +1,226 @@
+package snowrail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMarshalPaymentMethodAddsTypeDiscriminator(t *testing.T) {
+	tests := []struct {
+		name   string
+		method PaymentMethod
+		want   map[string]interface{}
+	}{
+		{
+			name: "evm",
+			method: EVMPayment{
+				Chain:     "base",
+				Token:     "USDC",
+				Sender:    "0xabc",
+				Recipient: "0xdef",
+			},
+			want: map[string]interface{}{
+				"type":      "evm",
+				"chain":     "base",
+				"token":     "USDC",
+				"sender":    "0xabc",
+				"recipient": "0xdef",
+			},
+		},
+		{
+			name: "bitcoin",
+			method: BitcoinPayment{
+				Address:               "bc1q...",
+				SatAmount:             150000,
+				ConfirmationsRequired: 3,
+			},
+			want: map[string]interface{}{
+				"type":                  "bitcoin",
+				"address":               "bc1q...",
+				"satAmount":             float64(150000),
+				"confirmationsRequired": float64(3),
+			},
+		},
+		{
+			name:   "lightning",
+			method: LightningPayment{BOLT11Invoice: "lnbc1..."},
+			want: map[string]interface{}{
+				"type":          "lightning",
+				"bolt11Invoice": "lnbc1...",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := marshalPaymentMethod(tt.method)
+			if err != nil {
+				t.Fatalf("marshalPaymentMethod: %v", err)
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d fields, want %d: %v", len(got), len(tt.want), got)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("field %q = %v (%T), want %v (%T)", k, got[k], got[k], v, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIntentWithMethodSendsDiscriminatedMethod(t *testing.T) {
+	var gotReq intentMethodRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		if r.Header.Get("Idempotency-Key") == "" {
+			t.Error("expected an Idempotency-Key header")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"intent":{"id":"intent_1","status":"pending"},"validation":{"decision":"allow"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+	result, err := c.CreateIntentWithMethod(context.Background(), "https://example.com", 100, LightningPayment{BOLT11Invoice: "lnbc1..."})
+	if err != nil {
+		t.Fatalf("CreateIntentWithMethod: %v", err)
+	}
+	if result.Intent.ID != "intent_1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	var method map[string]interface{}
+	if err := json.Unmarshal(gotReq.Method, &method); err != nil {
+		t.Fatalf("Unmarshal method: %v", err)
+	}
+	if method["type"] != "lightning" {
+		t.Fatalf("method type = %v, want %q", method["type"], "lightning")
+	}
+	if method["bolt11Invoice"] != "lnbc1..." {
+		t.Fatalf("unexpected method payload: %v", method)
+	}
+}
+
+func TestWatchBitcoinIntentClosesOnTerminalStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"confirmed","confirmations":3,"confirmationsRequired":3}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+	updates, err := c.WatchBitcoinIntent(context.Background(), "intent_1")
+	if err != nil {
+		t.Fatalf("WatchBitcoinIntent: %v", err)
+	}
+
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("expected a confirmation update before the channel closed")
+		}
+		if update.Status != "confirmed" || update.Err != nil {
+			t.Fatalf("unexpected update: %+v", update)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a confirmation update")
+	}
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatal("expected the channel to close after a terminal status")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel did not close after a terminal status")
+	}
+}
+
+func TestWatchBitcoinIntentStopsOnContextCancel(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&polls, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"pending","confirmations":0,"confirmationsRequired":3}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := c.WatchBitcoinIntent(ctx, "intent_1")
+	if err != nil {
+		t.Fatalf("WatchBitcoinIntent: %v", err)
+	}
+
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("expected a pending update before cancellation")
+		}
+		if update.Status != "pending" {
+			t.Fatalf("unexpected update: %+v", update)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a pending update")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatal("expected the channel to close after ctx cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel did not close after ctx cancellation")
+	}
+}
+
+func TestWatchBitcoinIntentClosesOnPollError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`not json`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+	updates, err := c.WatchBitcoinIntent(context.Background(), "intent_1")
+	if err != nil {
+		t.Fatalf("WatchBitcoinIntent: %v", err)
+	}
+
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("expected an error update before the channel closed")
+		}
+		if update.Err == nil {
+			t.Fatal("expected a decode error to be surfaced on the update")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("did not receive an error update")
+	}
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatal("expected the channel to close after a poll error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel did not close after a poll error")
+	}
+}
@@ -0,0 +1,10 @@
+package pool
+
+import "errors"
+
+// ErrClosed is returned by Submit once the pool has started draining.
+var ErrClosed = errors.New("pool: closed")
+
+// ErrHostEvicted is returned when a job targets a host that was
+// temporarily evicted after too many consecutive retryable failures.
+var ErrHostEvicted = errors.New("pool: host temporarily evicted")
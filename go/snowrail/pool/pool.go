@@ -0,0 +1,397 @@
+// Package pool provides a bounded worker pool that fronts SnowRailClient
+// calls with backpressure, per-host concurrency limits, retries with
+// jittered exponential backoff, and graceful shutdown.
+package pool
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Job is a unit of work submitted to the pool. Do is invoked with the
+// context passed to Submit (preserving caller context values) and should
+// return a result or an error classified by a Classifier.
+type Job struct {
+	// Do performs the work. It must be safe to call more than once, since
+	// retryable failures re-invoke it.
+	Do func(ctx context.Context) (interface{}, error)
+	// Host identifies the target host this job talks to, used for
+	// per-host concurrency caps and bad-host eviction. Jobs that don't
+	// talk to a remote host can leave this empty.
+	Host string
+}
+
+// Result is delivered on the channel returned by Submit once a Job
+// finishes, either successfully or after exhausting its retry budget.
+type Result struct {
+	Value    interface{}
+	Err      error
+	Attempts int
+}
+
+// Classifier decides whether an error is worth retrying. When
+// Options.Classify is nil, DefaultClassifier is used.
+type Classifier func(err error) (retryable bool)
+
+// Metrics is called after each job-level event so callers can wire up
+// counters without the pool taking a dependency on a particular metrics
+// library.
+type Metrics struct {
+	Queued   func()
+	Inflight func(delta int)
+	Retried  func()
+	Dropped  func()
+}
+
+// Options configures a WorkerPool.
+type Options struct {
+	// Concurrency is the total number of worker goroutines. Defaults to 4.
+	Concurrency int
+	// PerHostLimit caps how many jobs for the same Host may run at once.
+	// Zero means no per-host cap beyond Concurrency.
+	PerHostLimit int
+	// QueueSize bounds the number of jobs buffered before Submit blocks.
+	// Defaults to Concurrency.
+	QueueSize int
+	// MaxAttempts is the maximum number of tries per job, including the
+	// first. Defaults to 10.
+	//
+	// If a Job.Do calls a SnowRailClient method that has its own
+	// RetryPolicy, that policy's retries happen inside a single attempt
+	// here, compounding: MaxAttempts=3 wrapping a client with a 3-attempt
+	// RetryPolicy can retry a failing host up to 9 times. Pass a client
+	// built with ClientOptions{Retry: nil} (via NewClientWithOptions) to
+	// jobs so only this pool's retry budget applies.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the exponential backoff applied
+	// between retries. Defaults to 200ms and 30s.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// BadHostThreshold is the number of consecutive retryable failures
+	// for a host before it is temporarily evicted. Defaults to 5.
+	BadHostThreshold int
+	// BadHostCooldown is how long an evicted host is skipped. Defaults
+	// to 30s.
+	BadHostCooldown time.Duration
+	// Classify overrides the default retryable/permanent classification.
+	Classify Classifier
+	// Metrics receives pool instrumentation hooks. All fields are
+	// optional.
+	Metrics Metrics
+}
+
+func (o *Options) setDefaults() {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = o.Concurrency
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 10
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = 200 * time.Millisecond
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = 30 * time.Second
+	}
+	if o.BadHostThreshold <= 0 {
+		o.BadHostThreshold = 5
+	}
+	if o.BadHostCooldown <= 0 {
+		o.BadHostCooldown = 30 * time.Second
+	}
+	if o.Classify == nil {
+		o.Classify = DefaultClassifier
+	}
+}
+
+// DefaultClassifier treats a context.Canceled or context.DeadlineExceeded
+// returned as-is by Job.Do as non-retryable and every other error,
+// including wrapped context errors and decode errors, as worth a retry. Callers
+// talking to SnowRail's HTTP API should normally supply a Classifier
+// built on top of the snowrail/errors package instead.
+func DefaultClassifier(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+	return true
+}
+
+// hostState tracks per-host concurrency and health. waiting holds
+// submissions for this host that arrived while it was already at
+// PerHostLimit; they're dispatched as running jobs for the host finish,
+// rather than having the worker that dequeued them block in place.
+type hostState struct {
+	mu           sync.Mutex
+	inflight     int
+	failures     int
+	evictedUntil time.Time
+	waiting      []submission
+}
+
+// WorkerPool fronts a SnowRailClient (or any Job-producing caller) with a
+// bounded pool of workers, per-host concurrency limits, and retry with
+// jittered exponential backoff.
+type WorkerPool struct {
+	opts Options
+
+	// mu guards closed and serializes it against in-flight sends on
+	// jobs: Submit holds a read lock across its send so Drain (which
+	// takes the write lock before closing jobs) can never close the
+	// channel while a send is in flight.
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan submission
+
+	wg sync.WaitGroup
+
+	hostsMu sync.Mutex
+	hosts   map[string]*hostState
+
+	closeOnce sync.Once
+}
+
+type submission struct {
+	ctx    context.Context
+	job    Job
+	result chan Result
+}
+
+// New creates a WorkerPool and starts its worker goroutines. The client
+// argument is accepted for API symmetry with the rest of the SnowRail SDK
+// and future instrumentation, but jobs are free-form: callers decide what
+// client calls a Job.Do performs.
+func New(client interface{}, opts Options) *WorkerPool {
+	opts.setDefaults()
+	p := &WorkerPool{
+		opts:  opts,
+		jobs:  make(chan submission, opts.QueueSize),
+		hosts: make(map[string]*hostState),
+	}
+	for i := 0; i < opts.Concurrency; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// Submit enqueues a job and returns a channel that receives exactly one
+// Result once the job completes or exhausts its retry budget. Submit
+// blocks if the internal queue is full, providing backpressure.
+func (p *WorkerPool) Submit(ctx context.Context, job Job) (<-chan Result, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return nil, ErrClosed
+	}
+
+	resultCh := make(chan Result, 1)
+	sub := submission{ctx: ctx, job: job, result: resultCh}
+
+	if p.opts.Metrics.Queued != nil {
+		p.opts.Metrics.Queued()
+	}
+
+	select {
+	case p.jobs <- sub:
+		return resultCh, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Drain stops accepting new jobs, waits for all in-flight and queued jobs
+// to finish, and returns once every worker has exited.
+func (p *WorkerPool) Drain() {
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		close(p.jobs)
+		p.mu.Unlock()
+	})
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) worker() {
+	defer p.wg.Done()
+	for sub := range p.jobs {
+		p.run(sub)
+	}
+}
+
+// run executes sub, or — if its host is already at PerHostLimit — parks
+// it on that host's waiting list and returns immediately so this worker
+// goroutine can keep pulling jobs for other hosts instead of blocking.
+func (p *WorkerPool) run(sub submission) {
+	host := p.hostFor(sub.job.Host)
+	if host != nil && !p.acquireHostSlot(host, sub) {
+		return
+	}
+
+	p.execute(sub, host)
+}
+
+// acquireHostSlot reports whether sub may run now. If the host is
+// evicted it resolves sub with ErrHostEvicted and returns false. If the
+// host is at capacity it parks sub on host.waiting and returns false;
+// releaseHostSlot dispatches it later. Otherwise it reserves a slot and
+// returns true.
+func (p *WorkerPool) acquireHostSlot(host *hostState, sub submission) bool {
+	host.mu.Lock()
+	defer host.mu.Unlock()
+
+	if time.Now().Before(host.evictedUntil) {
+		sub.result <- Result{Err: ErrHostEvicted}
+		if p.opts.Metrics.Dropped != nil {
+			p.opts.Metrics.Dropped()
+		}
+		return false
+	}
+
+	if p.opts.PerHostLimit > 0 && host.inflight >= p.opts.PerHostLimit {
+		host.waiting = append(host.waiting, sub)
+		return false
+	}
+
+	host.inflight++
+	return true
+}
+
+// releaseHostSlot hands sub's just-freed host slot to the next waiting
+// submission for that host, if any, running it on a fresh goroutine so
+// the dispatch never blocks the caller. The slot is handed off, not
+// released-then-reacquired, so a freshly submitted job can't race a
+// longer-waiting one for it. If the host was evicted while a submission
+// waited, that submission is resolved with ErrHostEvicted and the next
+// one in line (if any) is tried instead. Only once no waiting submission
+// claims the slot is host.inflight actually decremented.
+func (p *WorkerPool) releaseHostSlot(host *hostState) {
+	for {
+		host.mu.Lock()
+		if len(host.waiting) == 0 {
+			host.inflight--
+			host.mu.Unlock()
+			return
+		}
+		next := host.waiting[0]
+		host.waiting = host.waiting[1:]
+		evicted := time.Now().Before(host.evictedUntil)
+		host.mu.Unlock()
+
+		if evicted {
+			next.result <- Result{Err: ErrHostEvicted}
+			if p.opts.Metrics.Dropped != nil {
+				p.opts.Metrics.Dropped()
+			}
+			continue
+		}
+
+		p.wg.Add(1)
+		go func(s submission) {
+			defer p.wg.Done()
+			p.execute(s, host)
+		}(next)
+		return
+	}
+}
+
+func (p *WorkerPool) execute(sub submission, host *hostState) {
+	if host != nil {
+		defer p.releaseHostSlot(host)
+	}
+
+	if p.opts.Metrics.Inflight != nil {
+		p.opts.Metrics.Inflight(1)
+		defer p.opts.Metrics.Inflight(-1)
+	}
+
+	var (
+		value    interface{}
+		err      error
+		attempts int
+	)
+	for attempts = 1; attempts <= p.opts.MaxAttempts; attempts++ {
+		value, err = sub.job.Do(sub.ctx)
+		if err == nil {
+			p.recordSuccess(host)
+			sub.result <- Result{Value: value, Attempts: attempts}
+			return
+		}
+		// Every failed attempt classified retryable counts toward bad-host
+		// eviction, even the last attempt that won't be retried — eviction
+		// tracks the host's health across jobs, not just mid-retry hiccups
+		// within a single job. Failures classified permanent do not count.
+		retryable := p.opts.Classify(err)
+		if retryable {
+			p.recordFailure(host)
+		}
+		if !retryable || attempts == p.opts.MaxAttempts {
+			break
+		}
+		if p.opts.Metrics.Retried != nil {
+			p.opts.Metrics.Retried()
+		}
+		select {
+		case <-time.After(p.backoff(attempts)):
+		case <-sub.ctx.Done():
+			err = sub.ctx.Err()
+			attempts++
+			goto done
+		}
+	}
+done:
+	sub.result <- Result{Err: err, Attempts: attempts}
+}
+
+func (p *WorkerPool) backoff(attempt int) time.Duration {
+	d := p.opts.BaseDelay << uint(attempt-1)
+	if d <= 0 || d > p.opts.MaxDelay {
+		d = p.opts.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) + 1))
+	return d/2 + jitter/2
+}
+
+func (p *WorkerPool) hostFor(host string) *hostState {
+	if host == "" {
+		return nil
+	}
+	p.hostsMu.Lock()
+	defer p.hostsMu.Unlock()
+	hs, ok := p.hosts[host]
+	if !ok {
+		hs = &hostState{}
+		p.hosts[host] = hs
+	}
+	return hs
+}
+
+func (p *WorkerPool) recordFailure(host *hostState) {
+	if host == nil {
+		return
+	}
+	host.mu.Lock()
+	host.failures++
+	if host.failures >= p.opts.BadHostThreshold {
+		host.evictedUntil = time.Now().Add(p.opts.BadHostCooldown)
+		host.failures = 0
+	}
+	host.mu.Unlock()
+}
+
+func (p *WorkerPool) recordSuccess(host *hostState) {
+	if host == nil {
+		return
+	}
+	host.mu.Lock()
+	host.failures = 0
+	host.mu.Unlock()
+}
@@ -0,0 +1,190 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolRetriesRetryableErrors(t *testing.T) {
+	var attempts int32
+	wp := New(nil, Options{
+		Concurrency: 1,
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	})
+	defer wp.Drain()
+
+	ch, err := wp.Submit(context.Background(), Job{
+		Do: func(ctx context.Context) (interface{}, error) {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				return nil, errors.New("transient")
+			}
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	res := <-ch
+	if res.Err != nil {
+		t.Fatalf("expected eventual success, got err: %v", res.Err)
+	}
+	if res.Value != "ok" {
+		t.Fatalf("expected value %q, got %v", "ok", res.Value)
+	}
+	if res.Attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", res.Attempts)
+	}
+}
+
+func TestWorkerPoolGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int32
+	wp := New(nil, Options{
+		Concurrency: 1,
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	})
+	defer wp.Drain()
+
+	ch, err := wp.Submit(context.Background(), Job{
+		Do: func(ctx context.Context) (interface{}, error) {
+			atomic.AddInt32(&attempts, 1)
+			return nil, errors.New("always fails")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	res := <-ch
+	if res.Err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestWorkerPoolEvictsBadHostAfterThreshold(t *testing.T) {
+	wp := New(nil, Options{
+		Concurrency:      1,
+		MaxAttempts:      1,
+		BadHostThreshold: 2,
+		BadHostCooldown:  time.Hour,
+	})
+	defer wp.Drain()
+
+	for i := 0; i < 2; i++ {
+		ch, err := wp.Submit(context.Background(), Job{
+			Do:   func(ctx context.Context) (interface{}, error) { return nil, errors.New("fail") },
+			Host: "bad.example.com",
+		})
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		res := <-ch
+		if res.Err == nil {
+			t.Fatal("expected job failure")
+		}
+	}
+
+	ch, err := wp.Submit(context.Background(), Job{
+		Do:   func(ctx context.Context) (interface{}, error) { return "should not run", nil },
+		Host: "bad.example.com",
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	res := <-ch
+	if !errors.Is(res.Err, ErrHostEvicted) {
+		t.Fatalf("expected ErrHostEvicted, got %v", res.Err)
+	}
+}
+
+// TestWorkerPoolPerHostLimitDoesNotStarveOtherHosts guards against the
+// worker busy-waiting in place when a host is at PerHostLimit: a slow job
+// for hostA must not delay an independent job for hostB.
+func TestWorkerPoolPerHostLimitDoesNotStarveOtherHosts(t *testing.T) {
+	wp := New(nil, Options{
+		Concurrency:  2,
+		PerHostLimit: 1,
+		MaxAttempts:  1,
+	})
+	defer wp.Drain()
+
+	blockA := make(chan struct{})
+	chA, err := wp.Submit(context.Background(), Job{
+		Do: func(ctx context.Context) (interface{}, error) {
+			<-blockA
+			return "a", nil
+		},
+		Host: "hostA",
+	})
+	if err != nil {
+		t.Fatalf("Submit hostA#1: %v", err)
+	}
+	if _, err := wp.Submit(context.Background(), Job{
+		Do: func(ctx context.Context) (interface{}, error) {
+			<-blockA
+			return "a2", nil
+		},
+		Host: "hostA",
+	}); err != nil {
+		t.Fatalf("Submit hostA#2: %v", err)
+	}
+
+	chB, err := wp.Submit(context.Background(), Job{
+		Do:   func(ctx context.Context) (interface{}, error) { return "b", nil },
+		Host: "hostB",
+	})
+	if err != nil {
+		t.Fatalf("Submit hostB: %v", err)
+	}
+
+	select {
+	case res := <-chB:
+		if res.Value != "b" {
+			t.Fatalf("expected hostB job to succeed, got %+v", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("hostB job starved behind saturated hostA")
+	}
+
+	close(blockA)
+	if res := <-chA; res.Value != "a" {
+		t.Fatalf("unexpected hostA result: %+v", res)
+	}
+}
+
+// TestWorkerPoolSubmitDuringDrainDoesNotPanic exercises the race the
+// review flagged: concurrent Submit calls racing a Drain must never send
+// on a closed channel.
+func TestWorkerPoolSubmitDuringDrainDoesNotPanic(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		wp := New(nil, Options{Concurrency: 2})
+
+		var wg sync.WaitGroup
+		for j := 0; j < 10; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ch, err := wp.Submit(context.Background(), Job{
+					Do: func(ctx context.Context) (interface{}, error) { return nil, nil },
+				})
+				if err == nil {
+					<-ch
+				}
+			}()
+		}
+
+		wp.Drain()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,20 @@
+package snowrail
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying id as the request ID. Methods
+// that accept a context (ValidateURLContext, CreateIntentContext,
+// HealthCheckContext) send it as an X-Request-ID header so logs on both
+// sides of the call correlate.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID previously attached with
+// WithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
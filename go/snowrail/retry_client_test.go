@@ -0,0 +1,113 @@
+package snowrail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	snowrailerrors "github.com/Colombia-Blockchain/snowrail-core/go/snowrail/errors"
+)
+
+func fastRetryPolicy() *snowrailerrors.RetryPolicy {
+	return &snowrailerrors.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestDoRetriesOn5xxThenSucceeds(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"ok","timestamp":"now","treasury":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: fastRetryPolicy()})
+
+	result, err := c.HealthCheckContext(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheckContext: %v", err)
+	}
+	if result.Status != "ok" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestDoRetriesOn429ThenGivesUp(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"code":"rate_limited","message":"slow down"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: fastRetryPolicy()})
+
+	_, err := c.HealthCheckContext(context.Background())
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if !snowrailerrors.IsRateLimited(err) {
+		t.Fatalf("expected a rate-limited error, got: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); int(got) != fastRetryPolicy().MaxAttempts {
+		t.Fatalf("expected %d requests, got %d", fastRetryPolicy().MaxAttempts, got)
+	}
+}
+
+func TestDoHonorsRetryAfterHeader(t *testing.T) {
+	var requests int32
+	var firstAttempt, secondAttempt time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondAttempt = time.Now()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: fastRetryPolicy()})
+
+	if _, err := c.HealthCheckContext(context.Background()); err != nil {
+		t.Fatalf("HealthCheckContext: %v", err)
+	}
+	if gap := secondAttempt.Sub(firstAttempt); gap < time.Second {
+		t.Fatalf("retry honored fast backoff instead of Retry-After: waited %v, want >= 1s", gap)
+	}
+}
+
+func TestDoDoesNotRetryWithoutRetryPolicy(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithOptions(srv.URL, ClientOptions{Retry: nil})
+
+	if _, err := c.HealthCheckContext(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected 1 request with no retry policy, got %d", got)
+	}
+}
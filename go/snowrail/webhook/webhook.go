@@ -0,0 +1,238 @@
+// Package webhook verifies and dispatches inbound SnowRail notifications
+// for payment intent lifecycle events.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of intent event a notification carries.
+type EventType string
+
+const (
+	EventIntentCreated     EventType = "intent.created"
+	EventIntentConfirmed   EventType = "intent.confirmed"
+	EventIntentFailed      EventType = "intent.failed"
+	EventValidationUpdated EventType = "validation.updated"
+)
+
+// IntentEvent is the payload of a SnowRail webhook notification.
+type IntentEvent struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	IntentID  string          `json:"intentId"`
+	Status    string          `json:"status,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EventHandler processes a single IntentEvent. Returning an error causes
+// the Handler to respond 5xx so SnowRail retries delivery.
+type EventHandler func(ctx context.Context, event *IntentEvent) error
+
+// Options configures a Handler.
+type Options struct {
+	// Tolerance bounds how far the signature timestamp may drift from
+	// now before a notification is rejected as a possible replay.
+	// Defaults to 5 minutes.
+	Tolerance time.Duration
+	// IdempotencyCacheSize caps how many recently seen event IDs are
+	// remembered for deduplication. Defaults to 1024.
+	IdempotencyCacheSize int
+}
+
+func (o *Options) setDefaults() {
+	if o.Tolerance <= 0 {
+		o.Tolerance = 5 * time.Minute
+	}
+	if o.IdempotencyCacheSize <= 0 {
+		o.IdempotencyCacheSize = 1024
+	}
+}
+
+// Handler is an http.Handler that verifies and dispatches SnowRail
+// webhook notifications.
+type Handler struct {
+	secret []byte
+	opts   Options
+
+	handlersMu sync.RWMutex
+	handlers   map[EventType][]EventHandler
+
+	seenMu sync.Mutex
+	seen   map[string]struct{}
+	seenQ  []string
+}
+
+// NewHandler creates a Handler that verifies inbound notifications with
+// HMAC-SHA256 over secret.
+func NewHandler(secret string, opts Options) *Handler {
+	opts.setDefaults()
+	return &Handler{
+		secret:   []byte(secret),
+		opts:     opts,
+		handlers: make(map[EventType][]EventHandler),
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// On registers handler to be called for every event of the given type. Handlers
+// for the same type are invoked in registration order.
+func (h *Handler) On(eventType EventType, handler EventHandler) {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], handler)
+}
+
+// OnIntentCreated registers a handler for intent.created events.
+func (h *Handler) OnIntentCreated(handler EventHandler) { h.On(EventIntentCreated, handler) }
+
+// OnIntentConfirmed registers a handler for intent.confirmed events.
+func (h *Handler) OnIntentConfirmed(handler EventHandler) { h.On(EventIntentConfirmed, handler) }
+
+// OnIntentFailed registers a handler for intent.failed events.
+func (h *Handler) OnIntentFailed(handler EventHandler) { h.On(EventIntentFailed, handler) }
+
+// OnValidationUpdated registers a handler for validation.updated events.
+func (h *Handler) OnValidationUpdated(handler EventHandler) { h.On(EventValidationUpdated, handler) }
+
+// ServeHTTP implements http.Handler. It verifies the request signature,
+// deduplicates by event ID, and dispatches to registered handlers.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r.Header.Get("X-SnowRail-Signature"), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event IntentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if h.isDuplicate(event.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), &event); err != nil {
+		// Forget the event so SnowRail's retry of it is dispatched again
+		// instead of being acknowledged as a duplicate.
+		h.seenMu.Lock()
+		delete(h.seen, event.ID)
+		for i, id := range h.seenQ {
+			if id == event.ID {
+				h.seenQ = append(h.seenQ[:i], h.seenQ[i+1:]...)
+				break
+			}
+		}
+		h.seenMu.Unlock()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the X-SnowRail-Signature header, formatted as
+// "t=<unix>,v1=<hex-hmac>", against HMAC-SHA256(secret, t + "." + body).
+func (h *Handler) verify(header string, body []byte) error {
+	if header == "" {
+		return fmt.Errorf("webhook: missing signature header")
+	}
+
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signature = kv[1]
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("webhook: malformed signature header")
+	}
+
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid timestamp: %w", err)
+	}
+	age := time.Since(time.Unix(t, 0))
+	if age < 0 {
+		age = -age
+	}
+	if age > h.opts.Tolerance {
+		return fmt.Errorf("webhook: signature timestamp outside tolerance")
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid signature encoding")
+	}
+	if subtle.ConstantTimeCompare(expected, got) != 1 {
+		return fmt.Errorf("webhook: signature mismatch")
+	}
+	return nil
+}
+
+func (h *Handler) isDuplicate(eventID string) bool {
+	if eventID == "" {
+		return false
+	}
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	if _, ok := h.seen[eventID]; ok {
+		return true
+	}
+	h.seen[eventID] = struct{}{}
+	h.seenQ = append(h.seenQ, eventID)
+	if len(h.seenQ) > h.opts.IdempotencyCacheSize {
+		oldest := h.seenQ[0]
+		h.seenQ = h.seenQ[1:]
+		delete(h.seen, oldest)
+	}
+	return false
+}
+
+func (h *Handler) dispatch(ctx context.Context, event *IntentEvent) error {
+	h.handlersMu.RLock()
+	handlers := append([]EventHandler(nil), h.handlers[event.Type]...)
+	h.handlersMu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return fmt.Errorf("webhook: handler for %s failed: %w", event.Type, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func header(timestamp int64, signature string) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, signature)
+}
+
+func TestHandlerVerifyAcceptsValidSignature(t *testing.T) {
+	h := NewHandler("whsec_test", Options{})
+	body := []byte(`{"id":"evt_1","type":"intent.created"}`)
+	now := time.Now().Unix()
+
+	err := h.verify(header(now, sign("whsec_test", now, body)), body)
+	if err != nil {
+		t.Fatalf("expected valid signature to be accepted, got: %v", err)
+	}
+}
+
+func TestHandlerVerifyRejectsExpiredTimestamp(t *testing.T) {
+	h := NewHandler("whsec_test", Options{Tolerance: time.Minute})
+	body := []byte(`{"id":"evt_1","type":"intent.created"}`)
+	old := time.Now().Add(-time.Hour).Unix()
+
+	err := h.verify(header(old, sign("whsec_test", old, body)), body)
+	if err == nil {
+		t.Fatal("expected an old timestamp outside tolerance to be rejected")
+	}
+}
+
+func TestHandlerVerifyRejectsTamperedBody(t *testing.T) {
+	h := NewHandler("whsec_test", Options{})
+	body := []byte(`{"id":"evt_1","type":"intent.created"}`)
+	now := time.Now().Unix()
+	signature := sign("whsec_test", now, body)
+
+	tampered := []byte(`{"id":"evt_1","type":"intent.failed"}`)
+	err := h.verify(header(now, signature), tampered)
+	if err == nil {
+		t.Fatal("expected a signature computed over a different body to be rejected")
+	}
+}
+
+func TestHandlerVerifyRejectsWrongSecret(t *testing.T) {
+	h := NewHandler("whsec_test", Options{})
+	body := []byte(`{"id":"evt_1","type":"intent.created"}`)
+	now := time.Now().Unix()
+
+	err := h.verify(header(now, sign("whsec_other", now, body)), body)
+	if err == nil {
+		t.Fatal("expected a signature from a different secret to be rejected")
+	}
+}
+
+func TestHandlerVerifyRejectsMalformedHeader(t *testing.T) {
+	h := NewHandler("whsec_test", Options{})
+	body := []byte(`{"id":"evt_1","type":"intent.created"}`)
+
+	cases := []string{
+		"",
+		"garbage",
+		"t=not-a-number,v1=abcd",
+		"v1=abcd",
+		"t=123456",
+	}
+	for _, header := range cases {
+		if err := h.verify(header, body); err == nil {
+			t.Errorf("expected malformed header %q to be rejected", header)
+		}
+	}
+}